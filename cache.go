@@ -0,0 +1,151 @@
+package locator
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache описывает интерфейс хранилища, в котором Cached сохраняет ответы сервисов
+// гео-локации между одинаковыми запросами.
+type Cache interface {
+	// Get возвращает сохранённый ответ по ключу и true, если он найден и ещё не устарел.
+	Get(key string) (*Response, bool)
+	// Set сохраняет ответ по ключу на время ttl.
+	Set(key string, r *Response, ttl time.Duration)
+}
+
+// cached оборачивает Locator кэшем ответов, чтобы повторные фиксы по тому же набору
+// сот и точек доступа Wi-Fi не уходили на сервер.
+type cached struct {
+	loc Locator
+	c   Cache
+	ttl time.Duration
+}
+
+// Cached возвращает Locator, который перед обращением к loc проверяет кэш c, а после
+// успешного ответа сохраняет его там на время ttl.
+func Cached(loc Locator, c Cache, ttl time.Duration) Locator {
+	return &cached{loc: loc, c: c, ttl: ttl}
+}
+
+// Get передает запрос через кэш и возвращает ответ из него либо от обёрнутого Locator.
+func (l *cached) Get(req Request) (*Response, error) {
+	return l.GetContext(context.Background(), req)
+}
+
+// GetContext делает то же самое, что и Get, но позволяет отменить запрос или ограничить
+// его по времени через переданный контекст.
+func (l *cached) GetContext(ctx context.Context, req Request) (*Response, error) {
+	key := cacheKey(req)
+	if resp, ok := l.c.Get(key); ok {
+		return resp, nil
+	}
+	resp, err := l.loc.GetContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	l.c.Set(key, resp, l.ttl)
+	return resp, nil
+}
+
+// cacheKey возвращает стабильный ключ кэша, вычисленный по отсортированному набору
+// вышек сотовой связи и точек доступа Wi-Fi из запроса — порядок их перечисления
+// в Request на ключ не влияет. Если запрос допускает определение по IP-адресу
+// (ConsiderIp), сам адрес тоже участвует в ключе — иначе два запроса без сот и точек
+// доступа, но с разных IP, получили бы одинаковый ключ и один клиент увидел бы
+// местоположение другого.
+func cacheKey(req Request) string {
+	cells := make([]string, len(req.CellTowers))
+	for i, c := range req.CellTowers {
+		cells[i] = fmt.Sprintf("%d:%d:%d:%d", c.MobileCountryCode, c.MobileNetworkCode, c.LocationAreaCode, c.CellId)
+	}
+	sort.Strings(cells)
+	aps := make([]string, len(req.WifiAccessPoints))
+	for i, a := range req.WifiAccessPoints {
+		aps[i] = strings.ToLower(a.MacAddress)
+	}
+	sort.Strings(aps)
+	var ip string
+	if req.ConsiderIp {
+		ip = req.IPAddress
+	}
+	h := sha256.New()
+	h.Write([]byte(strings.Join(cells, ",")))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(aps, ",")))
+	h.Write([]byte{0})
+	h.Write([]byte(ip))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// lruEntry описывает одну запись в LRUCache.
+type lruEntry struct {
+	key      string
+	response *Response
+	expires  time.Time
+}
+
+// LRUCache — потокобезопасная реализация Cache в памяти процесса с вытеснением давно
+// не использовавшихся записей при превышении capacity.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	list     *list.List
+	items    map[string]*list.Element
+}
+
+// NewLRUCache возвращает Cache, хранящий не более capacity записей одновременно.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		list:     list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get возвращает сохранённый ответ по ключу, если он есть и ещё не устарел.
+func (c *LRUCache) Get(key string) (*Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if time.Now().After(entry.expires) {
+		c.list.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.list.MoveToFront(el)
+	return entry.response, true
+}
+
+// Set сохраняет ответ по ключу на время ttl, вытесняя наименее недавно использованную
+// запись, если хранилище заполнено.
+func (c *LRUCache) Set(key string, r *Response, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.list.MoveToFront(el)
+		el.Value.(*lruEntry).response = r
+		el.Value.(*lruEntry).expires = time.Now().Add(ttl)
+		return
+	}
+	el := c.list.PushFront(&lruEntry{key: key, response: r, expires: time.Now().Add(ttl)})
+	c.items[key] = el
+	if c.list.Len() > c.capacity {
+		oldest := c.list.Back()
+		if oldest != nil {
+			c.list.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
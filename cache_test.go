@@ -0,0 +1,110 @@
+package locator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCacheKeyStableRegardlessOfOrder(t *testing.T) {
+	a := Request{
+		CellTowers: []CellTower{
+			{MobileCountryCode: 250, MobileNetworkCode: 1, LocationAreaCode: 1, CellId: 1},
+			{MobileCountryCode: 250, MobileNetworkCode: 1, LocationAreaCode: 1, CellId: 2},
+		},
+		WifiAccessPoints: []WifiAccessPoint{
+			{MacAddress: "AA:BB:CC:DD:EE:01"},
+			{MacAddress: "aa:bb:cc:dd:ee:02"},
+		},
+	}
+	b := Request{
+		CellTowers: []CellTower{
+			{MobileCountryCode: 250, MobileNetworkCode: 1, LocationAreaCode: 1, CellId: 2},
+			{MobileCountryCode: 250, MobileNetworkCode: 1, LocationAreaCode: 1, CellId: 1},
+		},
+		WifiAccessPoints: []WifiAccessPoint{
+			{MacAddress: "aa:bb:cc:dd:ee:02"},
+			{MacAddress: "AA:BB:CC:DD:EE:01"},
+		},
+	}
+	if cacheKey(a) != cacheKey(b) {
+		t.Fatal("cacheKey() differs for the same towers/APs in a different order")
+	}
+}
+
+func TestCacheKeyDiffersForDifferentFingerprints(t *testing.T) {
+	a := Request{CellTowers: []CellTower{{MobileCountryCode: 250, MobileNetworkCode: 1, LocationAreaCode: 1, CellId: 1}}}
+	b := Request{CellTowers: []CellTower{{MobileCountryCode: 250, MobileNetworkCode: 1, LocationAreaCode: 1, CellId: 2}}}
+	if cacheKey(a) == cacheKey(b) {
+		t.Fatal("cacheKey() is the same for different cell towers")
+	}
+}
+
+func TestCacheKeyDiffersByIPForIPOnlyLookups(t *testing.T) {
+	a := Request{ConsiderIp: true, IPAddress: "1.2.3.4"}
+	b := Request{ConsiderIp: true, IPAddress: "9.9.9.9"}
+	if cacheKey(a) == cacheKey(b) {
+		t.Fatal("cacheKey() is the same for two IP-only requests with different IPAddress")
+	}
+}
+
+func TestCacheKeyIgnoresIPWhenNotConsidered(t *testing.T) {
+	a := Request{ConsiderIp: false, IPAddress: "1.2.3.4"}
+	b := Request{ConsiderIp: false, IPAddress: "9.9.9.9"}
+	if cacheKey(a) != cacheKey(b) {
+		t.Fatal("cacheKey() should ignore IPAddress when ConsiderIp is false")
+	}
+}
+
+func TestCachedServesStoredResponseWithoutCallingLocatorAgain(t *testing.T) {
+	loc := &countingLocator{resp: &Response{Lat: 1, Lng: 2}}
+	c := NewLRUCache(10)
+	cachedLoc := Cached(loc, c, time.Hour)
+
+	req := Request{ConsiderIp: true, IPAddress: "1.2.3.4"}
+	if _, err := cachedLoc.Get(req); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if _, err := cachedLoc.Get(req); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if loc.calls != 1 {
+		t.Fatalf("underlying Locator called %d times, want 1 (second call should hit the cache)", loc.calls)
+	}
+}
+
+func TestCachedDoesNotLeakFixBetweenDifferentIPs(t *testing.T) {
+	loc := &countingLocator{resp: &Response{Lat: 55.0, Lng: 37.0}}
+	c := NewLRUCache(10)
+	cachedLoc := Cached(loc, c, time.Hour)
+
+	first, err := cachedLoc.Get(Request{ConsiderIp: true, IPAddress: "1.2.3.4"})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	loc.resp = &Response{Lat: 10.0, Lng: 20.0}
+	second, err := cachedLoc.Get(Request{ConsiderIp: true, IPAddress: "9.9.9.9"})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if first.Lat == second.Lat && first.Lng == second.Lng {
+		t.Fatal("a different IPAddress got back the first IP's cached fix")
+	}
+}
+
+// countingLocator is a Locator fake that counts how many times it was actually called,
+// so tests can tell a cache hit from a cache miss.
+type countingLocator struct {
+	resp  *Response
+	err   error
+	calls int
+}
+
+func (l *countingLocator) Get(req Request) (*Response, error) {
+	return l.GetContext(context.Background(), req)
+}
+
+func (l *countingLocator) GetContext(ctx context.Context, req Request) (*Response, error) {
+	l.calls++
+	return l.resp, l.err
+}
@@ -0,0 +1,263 @@
+package locator
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// URL сервисов прямого геокодирования (адрес → координаты).
+const (
+	GoogleGeocoding = "https://maps.googleapis.com/maps/api/geocode/json" // Google Geocoding API
+	AmapGeocoding   = "https://restapi.amap.com/v3/geocode/geo"           // AMap (高德地图)
+	BaiduGeocoding  = "https://api.map.baidu.com/geocoding/v3/"           // Baidu Map
+	QQGeocoding     = "https://apis.map.qq.com/ws/geocoder/v1/"           // Tencent (QQ) Map
+)
+
+// ForwardOption задаёт необязательный параметр запроса прямого геокодирования.
+type ForwardOption func(*forwardOptions)
+
+// forwardOptions собирает параметры, передаваемые через ForwardOption.
+type forwardOptions struct {
+	region string // код страны/региона, сужающий область поиска
+	lang   string // язык ответа
+}
+
+// WithRegion ограничивает поиск заданным регионом (например, "cn" или "ru").
+func WithRegion(region string) ForwardOption {
+	return func(o *forwardOptions) { o.region = region }
+}
+
+// WithLanguage задаёт язык, на котором должен быть возвращён адрес.
+func WithLanguage(lang string) ForwardOption {
+	return func(o *forwardOptions) { o.lang = lang }
+}
+
+// ForwardGeocoder описывает интерфейс, поддерживаемый всеми сервисами прямого
+// геокодирования.
+type ForwardGeocoder interface {
+	Forward(query string, opts ...ForwardOption) (*Response, error)
+}
+
+// NewForwardGeocoder возвращает новый инициализированный сервис прямого геокодирования.
+func NewForwardGeocoder(serviceUrl, apiKey string) (ForwardGeocoder, error) {
+	// проверяем, что URL в правильном формате
+	if _, err := url.ParseRequestURI(serviceUrl); err != nil {
+		return nil, err
+	}
+	return &forwardGeocoder{
+		serviceUrl: serviceUrl,
+		apiKey:     apiKey,
+		client: &http.Client{
+			Timeout: RequestTimeout,
+		},
+	}, nil
+}
+
+// forwardGeocoder реализует ForwardGeocoder для Google и для сервисов китайских
+// картографических провайдеров, выбирая формат запроса/ответа по serviceUrl.
+type forwardGeocoder struct {
+	serviceUrl string       // адрес для запроса сервиса
+	apiKey     string       // ключ доступа к API
+	client     *http.Client // HTTP-клиент
+}
+
+// Forward отправляет запрос прямого геокодирования и возвращает найденные координаты.
+func (g *forwardGeocoder) Forward(query string, opts ...ForwardOption) (*Response, error) {
+	options := &forwardOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	switch g.serviceUrl {
+	case AmapGeocoding:
+		return g.forwardAmap(query, options)
+	case BaiduGeocoding:
+		return g.forwardBaidu(query, options)
+	case QQGeocoding:
+		return g.forwardQQ(query, options)
+	default: // Google и совместимые с ним по формату ответа сервисы
+		return g.forwardGoogle(query, options)
+	}
+}
+
+// get выполняет GET-запрос к serviceUrl с переданными параметрами и возвращает тело ответа
+// либо ошибку, соответствующую коду состояния HTTP.
+func (g *forwardGeocoder) get(query url.Values) ([]byte, error) {
+	httpReq, err := http.NewRequest("GET", g.serviceUrl+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("User-Agent", UserAgent)
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case 200: // все хорошо — данные получены
+	case 400:
+		return nil, ErrBadRequest
+	case 403:
+		return nil, ErrForbidden
+	case 404:
+		return nil, ErrNotFound
+	default:
+		return nil, errors.New(http.StatusText(resp.StatusCode))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// forwardGoogle выполняет геокодирование через Google Geocoding API.
+func (g *forwardGeocoder) forwardGoogle(query string, options *forwardOptions) (*Response, error) {
+	q := url.Values{"address": {query}, "key": {g.apiKey}}
+	if options.region != "" {
+		q.Set("region", options.region)
+	}
+	if options.lang != "" {
+		q.Set("language", options.lang)
+	}
+	data, err := g.get(q)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Status  string `json:"status"`
+		Results []struct {
+			Geometry struct {
+				Location location `json:"location"`
+				Viewport struct {
+					Northeast location `json:"northeast"`
+					Southwest location `json:"southwest"`
+				} `json:"viewport"`
+			} `json:"geometry"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	if response.Status == "ZERO_RESULTS" || len(response.Results) == 0 {
+		return nil, ErrNotFound
+	}
+	if response.Status != "OK" {
+		return nil, errors.New(strings.ToLower(response.Status))
+	}
+	geometry := response.Results[0].Geometry
+	return &Response{
+		Lat: geometry.Location.Lat,
+		Lng: geometry.Location.Lng,
+		Bounds: &Bounds{
+			NorthEastLat: geometry.Viewport.Northeast.Lat,
+			NorthEastLng: geometry.Viewport.Northeast.Lng,
+			SouthWestLat: geometry.Viewport.Southwest.Lat,
+			SouthWestLng: geometry.Viewport.Southwest.Lng,
+		},
+	}, nil
+}
+
+// forwardAmap выполняет геокодирование через AMap (高德地图).
+func (g *forwardGeocoder) forwardAmap(query string, options *forwardOptions) (*Response, error) {
+	q := url.Values{"address": {query}, "key": {g.apiKey}}
+	if options.region != "" {
+		q.Set("city", options.region)
+	}
+	data, err := g.get(q)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Status   string `json:"status"`
+		Geocodes []struct {
+			Location string `json:"location"` // "lng,lat"
+		} `json:"geocodes"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	if response.Status != "1" || len(response.Geocodes) == 0 {
+		return nil, ErrNotFound
+	}
+	lng, lat, err := parseLngLat(response.Geocodes[0].Location, ",")
+	if err != nil {
+		return nil, err
+	}
+	return &Response{Lat: lat, Lng: lng}, nil
+}
+
+// forwardBaidu выполняет геокодирование через Baidu Map.
+func (g *forwardGeocoder) forwardBaidu(query string, options *forwardOptions) (*Response, error) {
+	q := url.Values{"address": {query}, "ak": {g.apiKey}, "output": {"json"}}
+	if options.region != "" {
+		q.Set("city", options.region)
+	}
+	data, err := g.get(q)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Status int `json:"status"`
+		Result struct {
+			Location location `json:"location"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	if response.Status != 0 {
+		return nil, ErrNotFound
+	}
+	// Accuracy оставляем нулевой: Baidu возвращает не расстояние в метрах, а
+	// безразмерный confidence (0-100), который с точностью определения не связан.
+	return &Response{
+		Lat: response.Result.Location.Lat,
+		Lng: response.Result.Location.Lng,
+	}, nil
+}
+
+// forwardQQ выполняет геокодирование через Tencent (QQ) Map.
+func (g *forwardGeocoder) forwardQQ(query string, options *forwardOptions) (*Response, error) {
+	q := url.Values{"address": {query}, "key": {g.apiKey}}
+	if options.region != "" {
+		q.Set("region", options.region)
+	}
+	data, err := g.get(q)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Status int `json:"status"`
+		Result struct {
+			Location location `json:"location"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	if response.Status != 0 {
+		return nil, ErrNotFound
+	}
+	// Accuracy оставляем нулевой: QQ возвращает не расстояние в метрах, а
+	// безразмерный reliability (1-10), который с точностью определения не связан.
+	return &Response{
+		Lat: response.Result.Location.Lat,
+		Lng: response.Result.Location.Lng,
+	}, nil
+}
+
+// parseLngLat разбирает строку вида "долгота,широта", возвращаемую AMap, на два числа.
+func parseLngLat(s, sep string) (lng, lat float64, err error) {
+	parts := strings.SplitN(s, sep, 2)
+	if len(parts) != 2 {
+		return 0, 0, errors.New("некорректный формат координат: " + s)
+	}
+	if lng, err = strconv.ParseFloat(parts[0], 64); err != nil {
+		return 0, 0, err
+	}
+	if lat, err = strconv.ParseFloat(parts[1], 64); err != nil {
+		return 0, 0, err
+	}
+	return lng, lat, nil
+}
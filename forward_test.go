@@ -0,0 +1,121 @@
+package locator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseLngLat(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantLng float64
+		wantLat float64
+		wantErr bool
+	}{
+		{"well formed", "116.397128,39.916527", 116.397128, 39.916527, false},
+		{"negative values", "-0.127647,51.507322", -0.127647, 51.507322, false},
+		{"missing separator", "116.397128 39.916527", 0, 0, true},
+		{"not a number", "abc,def", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lng, lat, err := parseLngLat(tt.in, ",")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseLngLat(%q) error = nil, want error", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseLngLat(%q) error = %v", tt.in, err)
+			}
+			if lng != tt.wantLng || lat != tt.wantLat {
+				t.Fatalf("parseLngLat(%q) = (%v, %v), want (%v, %v)", tt.in, lng, lat, tt.wantLng, tt.wantLat)
+			}
+		})
+	}
+}
+
+func TestForwardAmapParsesLocation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"1","geocodes":[{"location":"116.397128,39.916527"}]}`))
+	}))
+	defer srv.Close()
+
+	g := &forwardGeocoder{serviceUrl: srv.URL, client: srv.Client()}
+	resp, err := g.forwardAmap("天安门", &forwardOptions{})
+	if err != nil {
+		t.Fatalf("forwardAmap() error = %v", err)
+	}
+	if resp.Lat != 39.916527 || resp.Lng != 116.397128 {
+		t.Fatalf("forwardAmap() = %+v, want lat=39.916527 lng=116.397128", resp)
+	}
+}
+
+func TestForwardAmapNoResults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"1","geocodes":[]}`))
+	}))
+	defer srv.Close()
+
+	g := &forwardGeocoder{serviceUrl: srv.URL, client: srv.Client()}
+	if _, err := g.forwardAmap("nowhere", &forwardOptions{}); err != ErrNotFound {
+		t.Fatalf("forwardAmap() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestForwardDispatchesByServiceUrl(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK","results":[{"geometry":{"location":{"lat":1,"lng":2}}}]}`))
+	}))
+	defer srv.Close()
+
+	g, err := NewForwardGeocoder(srv.URL, "")
+	if err != nil {
+		t.Fatalf("NewForwardGeocoder() error = %v", err)
+	}
+	resp, err := g.Forward("somewhere")
+	if err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+	if resp.Lat != 1 || resp.Lng != 2 {
+		t.Fatalf("Forward() = %+v, want the Google-shaped response since serviceUrl isn't one of the known constants", resp)
+	}
+}
+
+func TestForwardBaiduLeavesAccuracyUnset(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":0,"result":{"location":{"lng":116.404,"lat":39.915},"confidence":80}}`))
+	}))
+	defer srv.Close()
+
+	g := &forwardGeocoder{serviceUrl: srv.URL, client: srv.Client()}
+	resp, err := g.forwardBaidu("anywhere", &forwardOptions{})
+	if err != nil {
+		t.Fatalf("forwardBaidu() error = %v", err)
+	}
+	if resp.Lat != 39.915 || resp.Lng != 116.404 {
+		t.Fatalf("forwardBaidu() = %+v, want lat=39.915 lng=116.404", resp)
+	}
+	if resp.Accuracy != 0 {
+		t.Fatalf("forwardBaidu() Accuracy = %v, want 0 (confidence is not a distance in meters)", resp.Accuracy)
+	}
+}
+
+func TestForwardQQLeavesAccuracyUnset(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":0,"result":{"location":{"lat":39.915,"lng":116.404},"reliability":5}}`))
+	}))
+	defer srv.Close()
+
+	g := &forwardGeocoder{serviceUrl: srv.URL, client: srv.Client()}
+	resp, err := g.forwardQQ("anywhere", &forwardOptions{})
+	if err != nil {
+		t.Fatalf("forwardQQ() error = %v", err)
+	}
+	if resp.Accuracy != 0 {
+		t.Fatalf("forwardQQ() Accuracy = %v, want 0 (reliability is not a distance in meters)", resp.Accuracy)
+	}
+}
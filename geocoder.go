@@ -0,0 +1,253 @@
+package locator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// URL сервисов обратного геокодирования.
+const (
+	Photon         = "https://photon.komoot.io/reverse"            // сервис на основе OpenStreetMap
+	Nominatim      = "https://nominatim.openstreetmap.org/reverse" // сервис на основе OpenStreetMap
+	YandexGeocoder = "https://geocode-maps.yandex.ru/1.x/"         // сервис геокодирования Яндекса
+)
+
+// Address описывает структурированный адрес, полученный в результате обратного геокодирования.
+type Address struct {
+	Country    string `json:"country,omitempty"`     // страна
+	Region     string `json:"region,omitempty"`      // регион или область
+	City       string `json:"city,omitempty"`        // город
+	District   string `json:"district,omitempty"`    // район
+	Street     string `json:"street,omitempty"`      // улица
+	House      string `json:"house,omitempty"`       // номер дома
+	PostalCode string `json:"postal_code,omitempty"` // почтовый индекс
+	Formatted  string `json:"formatted,omitempty"`   // адрес целиком в виде одной строки
+}
+
+// Geocoder описывает интерфейс, поддерживаемый всеми сервисами обратного геокодирования.
+type Geocoder interface {
+	Reverse(lat, lon float64, lang string) (*Address, error)
+}
+
+// NewGeocoder возвращает новый инициализированный сервис обратного геокодирования.
+func NewGeocoder(serviceUrl, apiKey string) (geocoder Geocoder, err error) {
+	if serviceUrl == YandexGeocoder { // для Яндекса возвращаем отдельный обработчик
+		return &yandexGeocoder{
+			serviceUrl: YandexGeocoder,
+			apiKey:     apiKey,
+			client: &http.Client{
+				Timeout: RequestTimeout,
+			},
+		}, nil
+	}
+	// проверяем, что URL в правильном формате
+	if _, err := url.ParseRequestURI(serviceUrl); err != nil {
+		return nil, err
+	}
+	return &osmGeocoder{ // остальные сервисы считаем совместимыми с Photon/Nominatim
+		serviceUrl: serviceUrl,
+		client: &http.Client{
+			Timeout: RequestTimeout,
+		},
+	}, nil
+}
+
+// osmGeocoder описывает обратное геокодирование через сервисы, совместимые с форматом
+// ответа Photon и Nominatim (GeoJSON).
+type osmGeocoder struct {
+	serviceUrl string       // адрес для запроса сервиса
+	client     *http.Client // HTTP-клиент
+}
+
+// osmResponse описывает формат ответа Photon/Nominatim в виде GeoJSON-коллекции.
+type osmResponse struct {
+	Features []struct {
+		Properties struct {
+			Country     string `json:"country"`
+			State       string `json:"state"`
+			City        string `json:"city"`
+			District    string `json:"district"`
+			Street      string `json:"street"`
+			HouseNumber string `json:"housenumber"`
+			PostCode    string `json:"postcode"`
+			Name        string `json:"name"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+// Reverse отправляет запрос обратного геокодирования и возвращает структурированный адрес.
+func (g *osmGeocoder) Reverse(lat, lon float64, lang string) (*Address, error) {
+	query := url.Values{
+		"lat": {strconv.FormatFloat(lat, 'f', -1, 64)},
+		"lon": {strconv.FormatFloat(lon, 'f', -1, 64)},
+	}
+	if lang != "" {
+		query.Set("lang", lang)
+	}
+	httpReq, err := http.NewRequest("GET", g.serviceUrl+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("User-Agent", UserAgent)
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case 200: // все хорошо — данные получены
+	case 400:
+		return nil, ErrBadRequest
+	case 403:
+		return nil, ErrForbidden
+	case 404:
+		return nil, ErrNotFound
+	default:
+		return nil, errors.New(http.StatusText(resp.StatusCode))
+	}
+	var response osmResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	if len(response.Features) == 0 {
+		return nil, ErrNotFound
+	}
+	props := response.Features[0].Properties
+	addr := &Address{
+		Country:    props.Country,
+		Region:     props.State,
+		City:       props.City,
+		District:   props.District,
+		Street:     props.Street,
+		House:      props.HouseNumber,
+		PostalCode: props.PostCode,
+		Formatted:  props.Name,
+	}
+	if addr.Formatted == "" {
+		addr.Formatted = formatAddress(addr)
+	}
+	return addr, nil
+}
+
+// yandexGeocoder описывает обратное геокодирование через сервис Яндекса.
+type yandexGeocoder struct {
+	serviceUrl string       // адрес для запроса сервиса
+	apiKey     string       // ключ доступа к API
+	client     *http.Client // HTTP-клиент
+}
+
+// yandexGeoResponse описывает формат ответа сервиса геокодирования Яндекса.
+type yandexGeoResponse struct {
+	Response struct {
+		GeoObjectCollection struct {
+			FeatureMember []struct {
+				GeoObject struct {
+					Name             string `json:"name"`
+					MetaDataProperty struct {
+						GeocoderMetaData struct {
+							Text           string `json:"text"`
+							AddressDetails struct {
+								Country struct {
+									CountryName        string `json:"CountryName"`
+									AdministrativeArea struct {
+										AdministrativeAreaName string `json:"AdministrativeAreaName"`
+										Locality               struct {
+											LocalityName string `json:"LocalityName"`
+											Thoroughfare struct {
+												ThoroughfareName string `json:"ThoroughfareName"`
+												Premise          struct {
+													PremiseNumber string `json:"PremiseNumber"`
+												} `json:"Premise"`
+											} `json:"Thoroughfare"`
+										} `json:"Locality"`
+									} `json:"AdministrativeArea"`
+									PostalCode struct {
+										PostalCodeNumber string `json:"PostalCodeNumber"`
+									} `json:"PostalCode"`
+								} `json:"Country"`
+							} `json:"AddressDetails"`
+						} `json:"GeocoderMetaData"`
+					} `json:"metaDataProperty"`
+				} `json:"GeoObject"`
+			} `json:"featureMember"`
+		} `json:"GeoObjectCollection"`
+	} `json:"response"`
+}
+
+// Reverse отправляет запрос обратного геокодирования и возвращает структурированный адрес.
+func (g *yandexGeocoder) Reverse(lat, lon float64, lang string) (*Address, error) {
+	query := url.Values{
+		"apikey": {g.apiKey},
+		"format": {"json"},
+		"geocode": {fmt.Sprintf("%s,%s",
+			strconv.FormatFloat(lon, 'f', -1, 64),
+			strconv.FormatFloat(lat, 'f', -1, 64))},
+	}
+	if lang != "" {
+		query.Set("lang", lang)
+	}
+	httpReq, err := http.NewRequest("GET", g.serviceUrl+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("User-Agent", UserAgent)
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case 200: // все хорошо — данные получены
+	case 400:
+		return nil, ErrBadRequest
+	case 403:
+		return nil, ErrForbidden
+	case 404:
+		return nil, ErrNotFound
+	default:
+		return nil, errors.New(http.StatusText(resp.StatusCode))
+	}
+	var response yandexGeoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	members := response.Response.GeoObjectCollection.FeatureMember
+	if len(members) == 0 {
+		return nil, ErrNotFound
+	}
+	details := members[0].GeoObject.MetaDataProperty.GeocoderMetaData.AddressDetails
+	locality := details.Country.AdministrativeArea.Locality
+	addr := &Address{
+		Country:    details.Country.CountryName,
+		Region:     details.Country.AdministrativeArea.AdministrativeAreaName,
+		City:       locality.LocalityName,
+		Street:     locality.Thoroughfare.ThoroughfareName,
+		House:      locality.Thoroughfare.Premise.PremiseNumber,
+		PostalCode: details.Country.PostalCode.PostalCodeNumber,
+		Formatted:  members[0].GeoObject.Name,
+	}
+	return addr, nil
+}
+
+// formatAddress собирает единую строку адреса из отдельных компонентов,
+// если сервис не вернул её в готовом виде.
+func formatAddress(addr *Address) string {
+	parts := make([]string, 0, 6)
+	for _, part := range []string{addr.Country, addr.Region, addr.City, addr.Street, addr.House} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	formatted := ""
+	for i, part := range parts {
+		if i > 0 {
+			formatted += ", "
+		}
+		formatted += part
+	}
+	return formatted
+}
@@ -0,0 +1,178 @@
+package locator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFormatAddress(t *testing.T) {
+	tests := []struct {
+		name string
+		addr *Address
+		want string
+	}{
+		{
+			name: "all parts present",
+			addr: &Address{Country: "Россия", Region: "Москва", City: "Москва", Street: "Тверская", House: "1"},
+			want: "Россия, Москва, Москва, Тверская, 1",
+		},
+		{
+			name: "missing middle parts",
+			addr: &Address{Country: "Россия", Street: "Тверская"},
+			want: "Россия, Тверская",
+		},
+		{
+			name: "nothing known",
+			addr: &Address{},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatAddress(tt.addr); got != tt.want {
+				t.Errorf("formatAddress(%+v) = %q, want %q", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOsmGeocoderReverseParsesFeature(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"features":[{"properties":{
+			"country":"Россия","state":"Москва","city":"Москва","district":"Тверской",
+			"street":"Тверская","housenumber":"1","postcode":"125009","name":"Tverskaya, 1"
+		}}]}`))
+	}))
+	defer srv.Close()
+
+	g := &osmGeocoder{serviceUrl: srv.URL, client: srv.Client()}
+	addr, err := g.Reverse(55.757, 37.615, "")
+	if err != nil {
+		t.Fatalf("Reverse() error = %v", err)
+	}
+	want := &Address{
+		Country: "Россия", Region: "Москва", City: "Москва", District: "Тверской",
+		Street: "Тверская", House: "1", PostalCode: "125009", Formatted: "Tverskaya, 1",
+	}
+	if *addr != *want {
+		t.Fatalf("Reverse() = %+v, want %+v", addr, want)
+	}
+}
+
+func TestOsmGeocoderReverseFillsFormattedWhenMissing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"features":[{"properties":{"country":"Россия","city":"Москва"}}]}`))
+	}))
+	defer srv.Close()
+
+	g := &osmGeocoder{serviceUrl: srv.URL, client: srv.Client()}
+	addr, err := g.Reverse(55.757, 37.615, "")
+	if err != nil {
+		t.Fatalf("Reverse() error = %v", err)
+	}
+	if addr.Formatted != "Россия, Москва" {
+		t.Fatalf("Reverse().Formatted = %q, want %q", addr.Formatted, "Россия, Москва")
+	}
+}
+
+func TestOsmGeocoderReverseNoFeatures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"features":[]}`))
+	}))
+	defer srv.Close()
+
+	g := &osmGeocoder{serviceUrl: srv.URL, client: srv.Client()}
+	if _, err := g.Reverse(0, 0, ""); err != ErrNotFound {
+		t.Fatalf("Reverse() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestOsmGeocoderReverseStatusCodes(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusBadRequest, ErrBadRequest},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+		g := &osmGeocoder{serviceUrl: srv.URL, client: srv.Client()}
+		_, err := g.Reverse(0, 0, "")
+		srv.Close()
+		if err != tt.want {
+			t.Errorf("status %d: Reverse() error = %v, want %v", tt.status, err, tt.want)
+		}
+	}
+}
+
+func TestYandexGeocoderReverseParsesFeature(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"response": map[string]any{
+				"GeoObjectCollection": map[string]any{
+					"featureMember": []map[string]any{
+						{
+							"GeoObject": map[string]any{
+								"name": "Тверская улица, 1",
+								"metaDataProperty": map[string]any{
+									"GeocoderMetaData": map[string]any{
+										"text": "Россия, Москва, Тверская улица, 1",
+										"AddressDetails": map[string]any{
+											"Country": map[string]any{
+												"CountryName": "Россия",
+												"AdministrativeArea": map[string]any{
+													"AdministrativeAreaName": "Москва",
+													"Locality": map[string]any{
+														"LocalityName": "Москва",
+														"Thoroughfare": map[string]any{
+															"ThoroughfareName": "Тверская улица",
+															"Premise":          map[string]any{"PremiseNumber": "1"},
+														},
+													},
+												},
+												"PostalCode": map[string]any{"PostalCodeNumber": "125009"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	g := &yandexGeocoder{serviceUrl: srv.URL, apiKey: "test", client: srv.Client()}
+	addr, err := g.Reverse(55.757, 37.615, "")
+	if err != nil {
+		t.Fatalf("Reverse() error = %v", err)
+	}
+	want := &Address{
+		Country: "Россия", Region: "Москва", City: "Москва",
+		Street: "Тверская улица", House: "1", PostalCode: "125009",
+		Formatted: "Тверская улица, 1",
+	}
+	if *addr != *want {
+		t.Fatalf("Reverse() = %+v, want %+v", addr, want)
+	}
+}
+
+func TestYandexGeocoderReverseNoFeatures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":{"GeoObjectCollection":{"featureMember":[]}}}`))
+	}))
+	defer srv.Close()
+
+	g := &yandexGeocoder{serviceUrl: srv.URL, apiKey: "test", client: srv.Client()}
+	if _, err := g.Reverse(0, 0, ""); err != ErrNotFound {
+		t.Fatalf("Reverse() error = %v, want ErrNotFound", err)
+	}
+}
@@ -2,6 +2,7 @@ package locator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -32,6 +33,7 @@ const (
 // Locator описывает интерфейс, поддерживаемый всеми типами сервисов гео-локации.
 type Locator interface {
 	Get(req Request) (*Response, error)
+	GetContext(ctx context.Context, req Request) (*Response, error)
 }
 
 // base описывает информацию о сервисе гео-локации, использующем стандартный тип
@@ -68,6 +70,22 @@ func New(serviceUrl, apiKey string) (locator Locator, err error) {
 
 // Get передает данные на сервер гео-локации и возвращает от него разобранный ответ или ошибку.
 func (l *base) Get(req Request) (*Response, error) {
+	return l.GetContext(context.Background(), req)
+}
+
+// GetContext делает то же самое, что и Get, но позволяет отменить запрос или ограничить
+// его по времени через переданный контекст.
+func (l *base) GetContext(ctx context.Context, req Request) (resp *Response, err error) {
+	provider := providerName(l.serviceUrl)
+	notifyRequest(provider, req)
+	start := time.Now()
+	defer func() {
+		if err != nil {
+			notifyError(provider, err)
+		} else {
+			notifyResponse(provider, resp, time.Since(start))
+		}
+	}()
 	req.ConsiderIp = !IgnoreIPMethod
 	if IgnoreIPMethod {
 		req.Fallbacks = &Fallbacks{
@@ -84,7 +102,7 @@ func (l *base) Get(req Request) (*Response, error) {
 	if err != nil {
 		return nil, err
 	}
-	httpReq, err := http.NewRequest("POST", l.serviceUrl, bytes.NewReader(data))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", l.serviceUrl, bytes.NewReader(data))
 	if err != nil {
 		return nil, err
 	}
@@ -93,12 +111,12 @@ func (l *base) Get(req Request) (*Response, error) {
 	if ipAddress != "" {
 		httpReq.Header.Set("X-Forwarded-For", ipAddress)
 	}
-	// resp, err := l.client.Post(l.serviceUrl, "application/json", bytes.NewReader(data))
-	resp, err := l.client.Do(httpReq)
+	// httpResp, err := l.client.Post(l.serviceUrl, "application/json", bytes.NewReader(data))
+	httpResp, err := l.client.Do(httpReq)
 	if err != nil {
 		return nil, err
 	}
-	switch resp.StatusCode {
+	switch httpResp.StatusCode {
 	case 200: // все хорошо — данные получены
 	case 400: // неверный формат данных запроса или плохой ключ
 		return nil, ErrBadRequest
@@ -107,13 +125,13 @@ func (l *base) Get(req Request) (*Response, error) {
 	case 404: // информация не найдена
 		return nil, ErrNotFound
 	default: // другая нехорошая ошибка
-		return nil, errors.New(http.StatusText(resp.StatusCode))
+		return nil, errors.New(http.StatusText(httpResp.StatusCode))
 	}
-	var response Response
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	resp.Body.Close()
+	var response mlsResponse
+	err = json.NewDecoder(httpResp.Body).Decode(&response)
+	httpResp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	return &response, nil
+	return response.toResponse(), nil
 }
@@ -0,0 +1,287 @@
+package locator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited возвращается, когда дневная квота запросов к провайдеру исчерпана
+// локальным ограничителем и запрос к нему не выполнялся.
+var ErrRateLimited = errors.New("превышен лимит запросов к провайдеру")
+
+// Mode задаёт режим работы Multi — как именно опрашивать несколько провайдеров
+// гео-локации.
+type Mode int
+
+// Режимы работы Multi.
+const (
+	Sequential Mode = iota // опрашивать провайдеров по очереди, пока один не ответит успешно
+	Racing                 // опрашивать всех провайдеров параллельно и взять самый точный ответ
+	Quorum                 // опрашивать всех провайдеров параллельно и требовать согласия K из них
+)
+
+// RateLimiter — простой ограничитель скорости запросов по алгоритму token bucket,
+// используемый для соблюдения дневных квот бесплатных сервисов гео-локации.
+type RateLimiter struct {
+	mu       sync.Mutex
+	tokens   float64
+	max      float64
+	rate     float64 // токенов в секунду
+	lastTime time.Time
+}
+
+// NewRateLimiter возвращает ограничитель, пропускающий не более rps запросов в секунду
+// с возможностью всплеска до burst запросов подряд.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		tokens:   float64(burst),
+		max:      float64(burst),
+		rate:     rps,
+		lastTime: time.Now(),
+	}
+}
+
+// Allow возвращает true и расходует один токен, если лимит ещё не исчерпан.
+func (r *RateLimiter) Allow() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.tokens = math.Min(r.max, r.tokens+now.Sub(r.lastTime).Seconds()*r.rate)
+	r.lastTime = now
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+// Provider связывает именованного провайдера гео-локации с его ограничителем скорости
+// запросов.
+type Provider struct {
+	Name    string       // имя провайдера, используется в сообщениях об ошибках
+	Locator Locator      // сам обработчик гео-локации
+	Limiter *RateLimiter // ограничитель дневной квоты, может быть nil
+}
+
+// NewProvider возвращает Provider с ограничителем на rps запросов в секунду и
+// всплеском до burst. Если rps <= 0, ограничение не применяется.
+func NewProvider(name string, loc Locator, rps float64, burst int) *Provider {
+	p := &Provider{Name: name, Locator: loc}
+	if rps > 0 {
+		p.Limiter = NewRateLimiter(rps, burst)
+	}
+	return p
+}
+
+// MultiError собирает ошибки, полученные от каждого из опрошенных провайдеров, чтобы
+// вызывающий код мог понять, кто из них и почему не ответил.
+type MultiError struct {
+	Errors map[string]error // ошибка по имени провайдера
+}
+
+func (e *MultiError) Error() string {
+	msg := "ни один провайдер гео-локации не вернул результат:"
+	for name, err := range e.Errors {
+		msg += fmt.Sprintf(" %s: %v;", name, err)
+	}
+	return msg
+}
+
+// Multi реализует Locator, опрашивая несколько провайдеров согласно заданному Mode.
+type Multi struct {
+	mode           Mode
+	providers      []*Provider
+	quorumK        int     // сколько провайдеров должны согласиться в режиме Quorum
+	quorumDistance float64 // допустимое расхождение между фиксами, метры
+}
+
+// MultiOption настраивает необязательные параметры Multi.
+type MultiOption func(*Multi)
+
+// WithQuorum задаёт для режима Quorum число согласующихся провайдеров k и максимальное
+// расстояние между их фиксами distance (в метрах), при котором они считаются совпадающими.
+func WithQuorum(k int, distance float64) MultiOption {
+	return func(m *Multi) {
+		m.quorumK = k
+		m.quorumDistance = distance
+	}
+}
+
+// NewMulti возвращает Locator, опрашивающий всех переданных провайдеров в заданном режиме.
+func NewMulti(mode Mode, providers []*Provider, opts ...MultiOption) *Multi {
+	m := &Multi{
+		mode:           mode,
+		providers:      providers,
+		quorumK:        2,
+		quorumDistance: 1000,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Get передает запрос провайдерам в соответствии с режимом Multi и возвращает
+// объединённый результат или ошибку.
+func (m *Multi) Get(req Request) (*Response, error) {
+	return m.GetContext(context.Background(), req)
+}
+
+// GetContext делает то же самое, что и Get, но позволяет отменить запрос или ограничить
+// его по времени через переданный контекст.
+func (m *Multi) GetContext(ctx context.Context, req Request) (*Response, error) {
+	switch m.mode {
+	case Racing:
+		return m.race(ctx, req)
+	case Quorum:
+		return m.quorum(ctx, req)
+	default:
+		return m.sequential(ctx, req)
+	}
+}
+
+// sequential опрашивает провайдеров по очереди, переходя к следующему при
+// ErrForbidden или ErrNotFound.
+func (m *Multi) sequential(ctx context.Context, req Request) (*Response, error) {
+	errs := &MultiError{Errors: make(map[string]error)}
+	for _, p := range m.providers {
+		if p.Limiter != nil && !p.Limiter.Allow() {
+			errs.Errors[p.Name] = ErrRateLimited
+			continue
+		}
+		resp, err := p.Locator.GetContext(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		errs.Errors[p.Name] = err
+		if !errors.Is(err, ErrForbidden) && !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrRateLimited) {
+			return nil, errs // неожиданная ошибка — дальше идти бессмысленно
+		}
+	}
+	return nil, errs
+}
+
+// providerResult хранит результат опроса одного провайдера для параллельных режимов.
+type providerResult struct {
+	name string
+	resp *Response
+	err  error
+}
+
+// poll параллельно опрашивает всех провайдеров, уважая их ограничители скорости, и
+// возвращает результаты в порядке, в котором они перечислены в Multi.providers.
+func (m *Multi) poll(ctx context.Context, req Request) []providerResult {
+	results := make([]providerResult, len(m.providers))
+	var wg sync.WaitGroup
+	for i, p := range m.providers {
+		wg.Add(1)
+		go func(i int, p *Provider) {
+			defer wg.Done()
+			if p.Limiter != nil && !p.Limiter.Allow() {
+				results[i] = providerResult{name: p.Name, err: ErrRateLimited}
+				return
+			}
+			resp, err := p.Locator.GetContext(ctx, req)
+			results[i] = providerResult{name: p.Name, resp: resp, err: err}
+		}(i, p)
+	}
+	wg.Wait()
+	return results
+}
+
+// race опрашивает всех провайдеров параллельно и возвращает ответ с наименьшей
+// погрешностью (accuracy) среди успешных.
+func (m *Multi) race(ctx context.Context, req Request) (*Response, error) {
+	results := m.poll(ctx, req)
+	errs := &MultiError{Errors: make(map[string]error)}
+	var best, fallback *Response // best — с известной точностью, fallback — любой успешный
+	for _, r := range results {
+		if r.err != nil {
+			errs.Errors[r.name] = r.err
+			continue
+		}
+		if fallback == nil {
+			fallback = r.resp
+		}
+		// Accuracy <= 0 значит "неизвестно" — такой фикс не должен побеждать фикс с
+		// настоящей, пусть и грубой, точностью только потому, что попался первым.
+		if r.resp.Accuracy <= 0 {
+			continue
+		}
+		if best == nil || r.resp.Accuracy < best.Accuracy {
+			best = r.resp
+		}
+	}
+	if best != nil {
+		return best, nil
+	}
+	if fallback != nil {
+		return fallback, nil
+	}
+	return nil, errs
+}
+
+// quorum опрашивает всех провайдеров параллельно и ищет среди успешных ответов группу
+// не менее чем из quorumK фиксов, каждый из которых лежит не далее quorumDistance метров
+// от одного и того же фикса группы, возвращая их усреднённое местоположение. Попарное
+// расстояние между остальными фиксами группы не проверяется.
+func (m *Multi) quorum(ctx context.Context, req Request) (*Response, error) {
+	results := m.poll(ctx, req)
+	errs := &MultiError{Errors: make(map[string]error)}
+	fixes := make([]*Response, 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			errs.Errors[r.name] = r.err
+			continue
+		}
+		fixes = append(fixes, r.resp)
+	}
+	for i, a := range fixes {
+		group := []*Response{a}
+		for j, b := range fixes {
+			if i == j {
+				continue
+			}
+			if haversine(a.Lat, a.Lng, b.Lat, b.Lng) <= m.quorumDistance {
+				group = append(group, b)
+			}
+		}
+		if len(group) >= m.quorumK {
+			return averageResponse(group), nil
+		}
+	}
+	if len(fixes) == 0 {
+		return nil, errs
+	}
+	errs.Errors["quorum"] = fmt.Errorf("нет согласия между провайдерами: %d успешных фиксов, нужно %d", len(fixes), m.quorumK)
+	return nil, errs
+}
+
+// averageResponse усредняет координаты нескольких ответов.
+func averageResponse(fixes []*Response) *Response {
+	var lat, lng, accuracy float64
+	for _, f := range fixes {
+		lat += f.Lat
+		lng += f.Lng
+		accuracy += f.Accuracy
+	}
+	n := float64(len(fixes))
+	return &Response{Lat: lat / n, Lng: lng / n, Accuracy: accuracy / n}
+}
+
+// haversine возвращает приблизительное расстояние между двумя точками на земной
+// поверхности в метрах.
+func haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadius = 6371000 // метров
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(lat2 - lat1)
+	dLng := rad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+	return earthRadius * c
+}
@@ -0,0 +1,135 @@
+package locator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeLocator — простая заглушка Locator для тестов, возвращающая заранее заданный
+// ответ или ошибку.
+type fakeLocator struct {
+	resp *Response
+	err  error
+}
+
+func (f *fakeLocator) Get(req Request) (*Response, error) {
+	return f.GetContext(context.Background(), req)
+}
+
+func (f *fakeLocator) GetContext(ctx context.Context, req Request) (*Response, error) {
+	return f.resp, f.err
+}
+
+func TestMultiRacePrefersKnownAccuracyRegardlessOfOrder(t *testing.T) {
+	zero := &fakeLocator{resp: &Response{Lat: 1, Lng: 1, Accuracy: 0}}
+	known := &fakeLocator{resp: &Response{Lat: 2, Lng: 2, Accuracy: 5}}
+
+	cases := []struct {
+		name      string
+		providers []*Provider
+	}{
+		{"zero-first", []*Provider{{Name: "zero", Locator: zero}, {Name: "known", Locator: known}}},
+		{"zero-second", []*Provider{{Name: "known", Locator: known}, {Name: "zero", Locator: zero}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := NewMulti(Racing, c.providers)
+			resp, err := m.Get(Request{})
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if resp.Accuracy != 5 {
+				t.Fatalf("Get() = %+v, want the 5m fix regardless of provider order", resp)
+			}
+		})
+	}
+}
+
+func TestMultiRaceFallsBackWhenNoAccuracyKnown(t *testing.T) {
+	a := &fakeLocator{resp: &Response{Lat: 1, Lng: 1}}
+	b := &fakeLocator{resp: &Response{Lat: 2, Lng: 2}}
+	m := NewMulti(Racing, []*Provider{{Name: "a", Locator: a}, {Name: "b", Locator: b}})
+	resp, err := m.Get(Request{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if resp == nil {
+		t.Fatal("Get() = nil, want a fallback response when every fix has unknown accuracy")
+	}
+}
+
+func TestMultiRaceAllFail(t *testing.T) {
+	a := &fakeLocator{err: ErrNotFound}
+	b := &fakeLocator{err: ErrForbidden}
+	m := NewMulti(Racing, []*Provider{{Name: "a", Locator: a}, {Name: "b", Locator: b}})
+	if _, err := m.Get(Request{}); err == nil {
+		t.Fatal("Get() error = nil, want an aggregated error when every provider fails")
+	}
+}
+
+func TestMultiQuorumRequiresAgreeingFixes(t *testing.T) {
+	// Две близкие точки (в пределах 1км) и одна далёкая — кворум из двух должен
+	// выбрать близкую пару и проигнорировать выброс.
+	near1 := &fakeLocator{resp: &Response{Lat: 55.751244, Lng: 37.618423}}
+	near2 := &fakeLocator{resp: &Response{Lat: 55.752244, Lng: 37.619423}}
+	far := &fakeLocator{resp: &Response{Lat: 59.9343, Lng: 30.3351}}
+
+	m := NewMulti(Quorum, []*Provider{
+		{Name: "near1", Locator: near1},
+		{Name: "near2", Locator: near2},
+		{Name: "far", Locator: far},
+	}, WithQuorum(2, 1000))
+
+	resp, err := m.Get(Request{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if haversine(resp.Lat, resp.Lng, 55.751244, 37.618423) > 1000 {
+		t.Fatalf("Get() = %+v, expected the averaged near fix, not the outlier", resp)
+	}
+}
+
+func TestMultiQuorumNoAgreement(t *testing.T) {
+	a := &fakeLocator{resp: &Response{Lat: 55.751244, Lng: 37.618423}}
+	b := &fakeLocator{resp: &Response{Lat: 59.9343, Lng: 30.3351}}
+	m := NewMulti(Quorum, []*Provider{{Name: "a", Locator: a}, {Name: "b", Locator: b}}, WithQuorum(2, 1000))
+	if _, err := m.Get(Request{}); err == nil {
+		t.Fatal("Get() error = nil, want an error when fewer than quorumK fixes agree")
+	}
+}
+
+func TestMultiSequentialFallsBackOnForbidden(t *testing.T) {
+	a := &fakeLocator{err: ErrForbidden}
+	b := &fakeLocator{resp: &Response{Lat: 1, Lng: 1, Accuracy: 10}}
+	m := NewMulti(Sequential, []*Provider{{Name: "a", Locator: a}, {Name: "b", Locator: b}})
+	resp, err := m.Get(Request{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if resp.Lat != 1 {
+		t.Fatalf("Get() = %+v, want fallback to the second provider", resp)
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	rl := NewRateLimiter(1000, 2) // высокая скорость восполнения, чтобы тест не был медленным
+	if !rl.Allow() || !rl.Allow() {
+		t.Fatal("Allow() = false within burst, want true")
+	}
+	if rl.Allow() {
+		t.Fatal("Allow() = true after burst exhausted, want false")
+	}
+	time.Sleep(5 * time.Millisecond) // при 1000rps этого достаточно для восполнения токена
+	if !rl.Allow() {
+		t.Fatal("Allow() = false after waiting for refill, want true")
+	}
+}
+
+func TestHaversineKnownDistance(t *testing.T) {
+	// Москва – Санкт-Петербург, около 635 км по прямой.
+	d := haversine(55.751244, 37.618423, 59.9343, 30.3351)
+	if d < 600000 || d > 670000 {
+		t.Fatalf("haversine() = %.0fm, want approximately 635000m", d)
+	}
+}
@@ -0,0 +1,193 @@
+package locator
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Observer описывает хуки, которые base и yandex вызывают вокруг каждого запроса к
+// провайдеру гео-локации — это позволяет собирать метрики и трассировку, не оборачивая
+// сам Locator.
+type Observer interface {
+	OnRequest(provider string, req Request)                            // запрос отправлен
+	OnResponse(provider string, resp *Response, latency time.Duration) // получен успешный ответ
+	OnError(provider string, err error)                                // запрос завершился ошибкой
+}
+
+// observersMu защищает observers от одновременных RegisterObserver и notify*,
+// вызываемых параллельно работающими Get/GetContext.
+var observersMu sync.RWMutex
+var observers []Observer
+
+// RegisterObserver добавляет Observer, который будет получать уведомления обо всех
+// последующих запросах ко всем провайдерам Locator в этом процессе. Безопасен для
+// вызова параллельно с запросами к Locator.
+func RegisterObserver(o Observer) {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	observers = append(observers, o)
+}
+
+// snapshotObservers возвращает копию текущего списка Observer, чтобы notify* могли
+// обходить его, не удерживая блокировку на время вызова сторонних хуков.
+func snapshotObservers() []Observer {
+	observersMu.RLock()
+	defer observersMu.RUnlock()
+	return append([]Observer(nil), observers...)
+}
+
+// notifyRequest оповещает всех зарегистрированных Observer об отправке запроса.
+func notifyRequest(provider string, req Request) {
+	for _, o := range snapshotObservers() {
+		o.OnRequest(provider, req)
+	}
+}
+
+// notifyResponse оповещает всех зарегистрированных Observer об успешном ответе.
+func notifyResponse(provider string, resp *Response, latency time.Duration) {
+	for _, o := range snapshotObservers() {
+		o.OnResponse(provider, resp, latency)
+	}
+}
+
+// notifyError оповещает всех зарегистрированных Observer об ошибке запроса.
+func notifyError(provider string, err error) {
+	for _, o := range snapshotObservers() {
+		o.OnError(provider, err)
+	}
+}
+
+// providerName возвращает короткое имя провайдера (хост сервиса) для использования в
+// качестве значения лейбла в метриках. Ключ доступа, который New() мог добавить в
+// serviceUrl в качестве query-параметра, в результат не попадает.
+func providerName(serviceUrl string) string {
+	if u, err := url.Parse(serviceUrl); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return serviceUrl
+}
+
+// errorCode классифицирует ошибку запроса в одно из фиксированного набора значений,
+// пригодных для использования в качестве лейбла метрики. В отличие от err.Error(), он
+// не может ни раздуть количество временных рядов (каждая новая сетевая ошибка — это
+// новый текст), ни случайно попасть в TSDB вместе с URL запроса, в который New()
+// подставляет ключ доступа.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrBadRequest):
+		return "400"
+	case errors.Is(err, ErrForbidden):
+		return "403"
+	case errors.Is(err, ErrNotFound):
+		return "404"
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "timeout"
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return "network" // ошибка транспорта: DNS, dial, TLS и т.п. — текст может содержать адрес с ключом
+	}
+	return "other"
+}
+
+// PrometheusObserver — реализация Observer, публикующая число запросов и ответов по
+// провайдерам и кодам статуса, а также гистограмму времени ответа.
+type PrometheusObserver struct {
+	requests  *prometheus.CounterVec
+	responses *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+}
+
+// NewPrometheusObserver создаёт метрики запросов, ответов и задержек провайдеров
+// гео-локации и регистрирует их в registerer. Если registerer равен nil, используется
+// prometheus.DefaultRegisterer. Если переданные метрики уже были зарегистрированы
+// (например, при создании второго PrometheusObserver в том же процессе), используются
+// уже существующие коллекторы вместо паники.
+func NewPrometheusObserver(registerer prometheus.Registerer) (*PrometheusObserver, error) {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+	requests, err := registerCounterVec(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "locator",
+		Name:      "requests_total",
+		Help:      "Общее число запросов к провайдерам гео-локации.",
+	}, []string{"provider"}))
+	if err != nil {
+		return nil, err
+	}
+	responses, err := registerCounterVec(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "locator",
+		Name:      "responses_total",
+		Help:      "Число завершённых запросов к провайдерам гео-локации по коду статуса.",
+	}, []string{"provider", "code"}))
+	if err != nil {
+		return nil, err
+	}
+	latency, err := registerHistogramVec(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "locator",
+		Name:      "request_duration_seconds",
+		Help:      "Время ответа провайдеров гео-локации, секунды.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider"}))
+	if err != nil {
+		return nil, err
+	}
+	return &PrometheusObserver{requests: requests, responses: responses, latency: latency}, nil
+}
+
+// registerCounterVec регистрирует c в r, возвращая уже зарегистрированный коллектор
+// вместо ошибки, если такая метрика была зарегистрирована ранее.
+func registerCounterVec(r prometheus.Registerer, c *prometheus.CounterVec) (*prometheus.CounterVec, error) {
+	if err := r.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(*prometheus.CounterVec); ok {
+				return existing, nil
+			}
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// registerHistogramVec делает то же самое, что и registerCounterVec, но для гистограмм.
+func registerHistogramVec(r prometheus.Registerer, c *prometheus.HistogramVec) (*prometheus.HistogramVec, error) {
+	if err := r.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(*prometheus.HistogramVec); ok {
+				return existing, nil
+			}
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// OnRequest учитывает отправленный запрос в счётчике requests_total.
+func (o *PrometheusObserver) OnRequest(provider string, req Request) {
+	o.requests.WithLabelValues(provider).Inc()
+}
+
+// OnResponse добавляет время ответа в гистограмму request_duration_seconds и учитывает
+// успешный ответ в responses_total с кодом "200".
+func (o *PrometheusObserver) OnResponse(provider string, resp *Response, latency time.Duration) {
+	o.latency.WithLabelValues(provider).Observe(latency.Seconds())
+	o.responses.WithLabelValues(provider, "200").Inc()
+}
+
+// OnError учитывает ошибку в responses_total под классифицированным кодом — в том
+// числе "403" для ErrForbidden, по частоте которого удобно отслеживать исчерпание
+// дневной квоты провайдера. Лейблом никогда не становится исходный текст ошибки,
+// чтобы в него не попал URL запроса с ключом доступа и чтобы число временных рядов
+// оставалось ограниченным.
+func (o *PrometheusObserver) OnError(provider string, err error) {
+	o.responses.WithLabelValues(provider, errorCode(err)).Inc()
+}
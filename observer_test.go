@@ -0,0 +1,101 @@
+package locator
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingObserver counts how many times each hook was called, guarded by its own
+// mutex since notify* may invoke it from multiple goroutines concurrently.
+type countingObserver struct {
+	mu                          sync.Mutex
+	requests, responses, errors int
+}
+
+func (o *countingObserver) OnRequest(provider string, req Request) {
+	o.mu.Lock()
+	o.requests++
+	o.mu.Unlock()
+}
+
+func (o *countingObserver) OnResponse(provider string, resp *Response, latency time.Duration) {
+	o.mu.Lock()
+	o.responses++
+	o.mu.Unlock()
+}
+
+func (o *countingObserver) OnError(provider string, err error) {
+	o.mu.Lock()
+	o.errors++
+	o.mu.Unlock()
+}
+
+// TestRegisterObserverConcurrentWithNotify reproduces concurrent RegisterObserver
+// calls racing against in-flight notify* calls — the scenario of a service
+// registering observers while requests are already in flight. Run with -race.
+func TestRegisterObserverConcurrentWithNotify(t *testing.T) {
+	defer func(saved []Observer) { observers = saved }(observers)
+	observers = nil
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			RegisterObserver(&countingObserver{})
+		}()
+		go func() {
+			defer wg.Done()
+			notifyRequest("test", Request{})
+		}()
+		go func() {
+			defer wg.Done()
+			notifyError("test", ErrNotFound)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestErrorCode(t *testing.T) {
+	timeoutErr := &net.DNSError{IsTimeout: true}
+	networkErr := &url.Error{Op: "Post", URL: "http://example.invalid", Err: errors.New("dial tcp: connection refused")}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"bad request", ErrBadRequest, "400"},
+		{"forbidden", ErrForbidden, "403"},
+		{"not found", ErrNotFound, "404"},
+		{"timeout", timeoutErr, "timeout"},
+		{"network", networkErr, "network"},
+		{"other", errors.New("something else"), "other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorCode(tt.err); got != tt.want {
+				t.Errorf("errorCode(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderName(t *testing.T) {
+	tests := []struct {
+		serviceUrl string
+		want       string
+	}{
+		{"https://www.googleapis.com/geolocation/v1/geolocate?key=SECRET", "www.googleapis.com"},
+		{"not-a-url even with spaces", "not-a-url even with spaces"},
+	}
+	for _, tt := range tests {
+		if got := providerName(tt.serviceUrl); got != tt.want {
+			t.Errorf("providerName(%q) = %q, want %q", tt.serviceUrl, got, tt.want)
+		}
+	}
+}
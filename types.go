@@ -0,0 +1,83 @@
+package locator
+
+// CellTower описывает информацию о вышке сотовой связи, используемую при определении
+// местоположения.
+type CellTower struct {
+	MobileCountryCode int `json:"mobileCountryCode"`        // код страны оператора (MCC)
+	MobileNetworkCode int `json:"mobileNetworkCode"`        // код сети оператора (MNC)
+	LocationAreaCode  int `json:"locationAreaCode"`         // код зоны нахождения (LAC)
+	CellId            int `json:"cellId"`                   // идентификатор соты (CID)
+	Age               int `json:"age,omitempty"`            // время с момента последнего наблюдения, мс
+	SignalStrength    int `json:"signalStrength,omitempty"` // уровень сигнала, дБм
+	TimingAdvance     int `json:"timingAdvance,omitempty"`  // опережение по времени
+}
+
+// WifiAccessPoint описывает информацию о точке доступа Wi-Fi, используемую при
+// определении местоположения.
+type WifiAccessPoint struct {
+	MacAddress         string `json:"macAddress"`                   // MAC-адрес точки доступа (BSSID)
+	SignalStrength     int    `json:"signalStrength,omitempty"`     // уровень сигнала, дБм
+	Age                int    `json:"age,omitempty"`                // время с момента последнего наблюдения, мс
+	Channel            int    `json:"channel,omitempty"`            // номер канала
+	SignalToNoiseRatio int    `json:"signalToNoiseRatio,omitempty"` // отношение сигнал/шум
+}
+
+// Fallbacks описывает, какие запасные способы определения местоположения разрешено
+// использовать сервису, если по переданным данным точное местоположение найти не удалось.
+type Fallbacks struct {
+	LAC bool `json:"lacf"` // разрешить приблизительное определение по зоне нахождения (LAC)
+	IP  bool `json:"ipf"`  // разрешить определение по IP-адресу
+}
+
+// Request описывает данные, передаваемые сервису гео-локации для определения
+// местоположения по сотам и точкам доступа Wi-Fi.
+type Request struct {
+	RadioType             string            `json:"radioType,omitempty"`             // тип сотовой сети: gsm, cdma, wcdma, lte
+	Carrier               string            `json:"carrier,omitempty"`               // название оператора
+	HomeMobileCountryCode int               `json:"homeMobileCountryCode,omitempty"` // код страны оператора домашней сети
+	HomeMobileNetworkCode int               `json:"homeMobileNetworkCode,omitempty"` // код оператора домашней сети
+	ConsiderIp            bool              `json:"considerIp,omitempty"`            // учитывать ли IP-адрес при определении
+	CellTowers            []CellTower       `json:"cellTowers,omitempty"`            // список видимых вышек сотовой связи
+	WifiAccessPoints      []WifiAccessPoint `json:"wifiAccessPoints,omitempty"`      // список видимых точек доступа Wi-Fi
+	Fallbacks             *Fallbacks        `json:"fallbacks,omitempty"`             // разрешённые запасные способы определения
+	IPAddress             string            `json:"-"`                               // IP-адрес, передаваемый заголовком X-Forwarded-For
+}
+
+// Bounds описывает прямоугольную область, ограничивающую найденное местоположение.
+type Bounds struct {
+	NorthEastLat float64 `json:"northeast_lat"` // широта северо-восточного угла
+	NorthEastLng float64 `json:"northeast_lng"` // долгота северо-восточного угла
+	SouthWestLat float64 `json:"southwest_lat"` // широта юго-западного угла
+	SouthWestLng float64 `json:"southwest_lng"` // долгота юго-западного угла
+}
+
+// Response описывает результат определения местоположения, общий как для запросов
+// по сотам/Wi-Fi, так и для геокодирования адреса.
+type Response struct {
+	Lat      float64 `json:"lat"`              // широта
+	Lng      float64 `json:"lng"`              // долгота
+	Accuracy float64 `json:"accuracy"`         // точность определения, метры
+	Bounds   *Bounds `json:"bounds,omitempty"` // ограничивающая область, если она известна
+}
+
+// location описывает вложенную структуру `{lat, lng}`, которую возвращают Mozilla и
+// Google в поле `location`.
+type location struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// mlsResponse описывает формат ответа сервисов, совместимых с Mozilla Location Service.
+type mlsResponse struct {
+	Location location `json:"location"`
+	Accuracy float64  `json:"accuracy"`
+}
+
+// toResponse приводит ответ, пришедший в формате Mozilla/Google, к общему типу Response.
+func (r mlsResponse) toResponse() *Response {
+	return &Response{
+		Lat:      r.Location.Lat,
+		Lng:      r.Location.Lng,
+		Accuracy: r.Accuracy,
+	}
+}
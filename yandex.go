@@ -0,0 +1,139 @@
+package locator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// yandex описывает обработчик сервиса гео-локации Яндекса, использующего собственный
+// формат запроса, отличный от Mozilla/Google.
+type yandex struct {
+	apiKey string       // ключ доступа к API
+	client *http.Client // HTTP-клиент
+}
+
+// yandexRequest описывает формат запроса, ожидаемый сервисом гео-локации Яндекса.
+type yandexRequest struct {
+	Common struct {
+		Version string `json:"version"`
+		APIKey  string `json:"api_key"`
+	} `json:"common"`
+	GsmCells []struct {
+		CountryCode int `json:"countrycode"`
+		OperatorId  int `json:"operatorid"`
+		LAC         int `json:"lac"`
+		CellId      int `json:"cellid"`
+		Signal      int `json:"signal_strength,omitempty"`
+	} `json:"gsm_cells,omitempty"`
+	WifiNetworks []struct {
+		Mac    string `json:"mac"`
+		Signal int    `json:"signal_strength,omitempty"`
+	} `json:"wifi_networks,omitempty"`
+	IP string `json:"ip,omitempty"`
+}
+
+// yandexResponse описывает формат ответа сервиса гео-локации Яндекса.
+type yandexResponse struct {
+	Position *struct {
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Precision float64 `json:"precision"`
+	} `json:"position"`
+}
+
+// toYandexRequest приводит общий Request к формату, ожидаемому API Яндекса.
+func toYandexRequest(apiKey string, req Request) yandexRequest {
+	var yreq yandexRequest
+	yreq.Common.Version = "1.0"
+	yreq.Common.APIKey = apiKey
+	for _, cell := range req.CellTowers {
+		yreq.GsmCells = append(yreq.GsmCells, struct {
+			CountryCode int `json:"countrycode"`
+			OperatorId  int `json:"operatorid"`
+			LAC         int `json:"lac"`
+			CellId      int `json:"cellid"`
+			Signal      int `json:"signal_strength,omitempty"`
+		}{
+			CountryCode: cell.MobileCountryCode,
+			OperatorId:  cell.MobileNetworkCode,
+			LAC:         cell.LocationAreaCode,
+			CellId:      cell.CellId,
+			Signal:      cell.SignalStrength,
+		})
+	}
+	for _, ap := range req.WifiAccessPoints {
+		yreq.WifiNetworks = append(yreq.WifiNetworks, struct {
+			Mac    string `json:"mac"`
+			Signal int    `json:"signal_strength,omitempty"`
+		}{
+			Mac:    ap.MacAddress,
+			Signal: ap.SignalStrength,
+		})
+	}
+	if req.ConsiderIp {
+		yreq.IP = req.IPAddress
+	}
+	return yreq
+}
+
+// Get передает данные на сервер гео-локации Яндекса и возвращает от него разобранный
+// ответ или ошибку.
+func (l *yandex) Get(req Request) (*Response, error) {
+	return l.GetContext(context.Background(), req)
+}
+
+// GetContext делает то же самое, что и Get, но позволяет отменить запрос или ограничить
+// его по времени через переданный контекст.
+func (l *yandex) GetContext(ctx context.Context, req Request) (result *Response, err error) {
+	notifyRequest("yandex", req)
+	start := time.Now()
+	defer func() {
+		if err != nil {
+			notifyError("yandex", err)
+		} else {
+			notifyResponse("yandex", result, time.Since(start))
+		}
+	}()
+	data, err := json.Marshal(toYandexRequest(l.apiKey, req))
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", Yandex, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("User-Agent", UserAgent)
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := l.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case 200: // все хорошо — данные получены
+	case 400: // неверный формат данных запроса или плохой ключ
+		return nil, ErrBadRequest
+	case 403: // исчерпан лимит запросов
+		return nil, ErrForbidden
+	case 404: // информация не найдена
+		return nil, ErrNotFound
+	default: // другая нехорошая ошибка
+		return nil, errors.New(http.StatusText(resp.StatusCode))
+	}
+	var response yandexResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	if response.Position == nil {
+		return nil, ErrNotFound
+	}
+	return &Response{
+		Lat:      response.Position.Latitude,
+		Lng:      response.Position.Longitude,
+		Accuracy: response.Position.Precision,
+	}, nil
+}